@@ -0,0 +1,53 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeCoffeeConfig configures how the node-coffee controller reconciles
+// a set of nodes: which reverse DNS domain to publish, which Scaleway
+// Database IDs to keep node IPs allow-listed in, and which reserved IPs
+// it is allowed to attach. It supersedes the REVERSE_IP_DOMAIN,
+// DATABASE_IDS and RESERVED_IPS_POOL env vars, which remain the default
+// config when no NodeCoffeeConfig matches a node.
+type NodeCoffeeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodeCoffeeConfigSpec `json:"spec"`
+}
+
+// NodeCoffeeConfigSpec is the desired state of a NodeCoffeeConfig.
+type NodeCoffeeConfigSpec struct {
+	// ReverseIPDomain is the domain used to build each matched node's
+	// reverse DNS record, e.g. "node.example.com".
+	ReverseIPDomain string `json:"reverseIPDomain,omitempty"`
+
+	// DatabaseIDs are the Scaleway Database Instance IDs whose ACLs
+	// should allow-list the matched nodes' public IPs.
+	DatabaseIDs []string `json:"databaseIDs,omitempty"`
+
+	// ReservedIPsPool is the set of Scaleway reserved IP addresses the
+	// controller is allowed to attach to matched nodes.
+	ReservedIPsPool []string `json:"reservedIPsPool,omitempty"`
+
+	// NodeSelector restricts this config to nodes matching the given
+	// labels. An empty selector matches every node. When several configs
+	// match a node, the most recently created one applies, so a config
+	// with an empty selector overrides every older config, whatever its
+	// selector.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeCoffeeConfigList is a list of NodeCoffeeConfig.
+type NodeCoffeeConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NodeCoffeeConfig `json:"items"`
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"os"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	klog "k8s.io/klog/v2"
+
+	nodecoffeev1alpha1 "github.com/abarbare/scaleway-k8s-node-coffee/pkg/apis/nodecoffee/v1alpha1"
+	nodecoffeeclientset "github.com/abarbare/scaleway-k8s-node-coffee/pkg/generated/clientset/versioned"
+	nodecoffeeinformers "github.com/abarbare/scaleway-k8s-node-coffee/pkg/generated/informers/externalversions"
+	nodecoffeelisters "github.com/abarbare/scaleway-k8s-node-coffee/pkg/generated/listers/nodecoffee/v1alpha1"
+)
+
+// ConfigNamespaceEnv overrides the namespace the controller watches for
+// NodeCoffeeConfig objects. Defaults to defaultConfigNamespace.
+const ConfigNamespaceEnv = "NODE_COFFEE_CONFIG_NAMESPACE"
+
+const defaultConfigNamespace = "kube-system"
+
+// configResyncPeriod is 0 because NodeCoffeeConfig changes are pushed
+// through enqueueNodesMatchingConfig on add/update/delete; there's no
+// need to periodically re-list.
+const configResyncPeriod = 0
+
+// resolvedConfig is the effective reverse-DNS domain, database ACL
+// targets and reserved IP pool for a single node, after overlaying any
+// matching NodeCoffeeConfig on top of the env-derived defaults.
+type resolvedConfig struct {
+	reverseIPDomain string
+	databaseIDs     []string
+	reservedIPs     []string
+}
+
+func configNamespaceFromEnv() string {
+	if v := os.Getenv(ConfigNamespaceEnv); v != "" {
+		return v
+	}
+	return defaultConfigNamespace
+}
+
+// newConfigInformer builds the shared informer and lister for
+// NodeCoffeeConfig objects in namespace from the generated
+// client-gen/informer-gen/lister-gen code, and wires onChange to every
+// add/update/delete.
+func newConfigInformer(client nodecoffeeclientset.Interface, namespace string, onChange func(obj interface{})) (nodecoffeelisters.NodeCoffeeConfigLister, cache.SharedIndexInformer) {
+	factory := nodecoffeeinformers.NewFilteredSharedInformerFactory(client, configResyncPeriod, namespace, nil)
+	configInformer := factory.Nodecoffee().V1alpha1().NodeCoffeeConfigs()
+
+	configInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    onChange,
+		UpdateFunc: func(old interface{}, new interface{}) { onChange(old); onChange(new) },
+		DeleteFunc: onChange,
+	})
+
+	return configInformer.Lister(), configInformer.Informer()
+}
+
+// enqueueNodesMatchingConfig re-enqueues every currently known node whose
+// labels match obj's NodeSelector, so that edits to a NodeCoffeeConfig
+// (including deletion, which falls back to the next matching config or
+// the env defaults) take effect without waiting for the next node event.
+func (c *Controller) enqueueNodesMatchingConfig(obj interface{}) {
+	config, ok := obj.(*nodecoffeev1alpha1.NodeCoffeeConfig)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			config, ok = tombstone.Obj.(*nodecoffeev1alpha1.NodeCoffeeConfig)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+
+	selector := labels.SelectorFromValidatedSet(config.Spec.NodeSelector)
+	for _, obj := range c.indexer.List() {
+		node, ok := obj.(*v1.Node)
+		if !ok {
+			continue
+		}
+		if selector.Matches(labels.Set(node.Labels)) {
+			for _, task := range tasksForNode(node.Name) {
+				c.queue.Add(task)
+			}
+		}
+	}
+}
+
+// configIsNewer reports whether a should win a tie-break over b when both
+// match a node: most recent CreationTimestamp first, falling back to the
+// lexicographically greater namespace/name so that two configs created
+// within the same second (CreationTimestamp only has 1-second resolution)
+// resolve deterministically instead of depending on indexer iteration
+// order, which is randomized per call.
+func configIsNewer(a, b *nodecoffeev1alpha1.NodeCoffeeConfig) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.After(b.CreationTimestamp.Time)
+	}
+	return a.Namespace+"/"+a.Name > b.Namespace+"/"+b.Name
+}
+
+// resolveConfig returns the effective config for nodeName: the
+// NodeCoffeeConfig in configNamespace whose NodeSelector matches the
+// node (ties broken deterministically by configIsNewer), overlaid on the
+// env-derived defaults. It falls back to the defaults entirely when no
+// config matches or the node isn't known yet.
+func (c *Controller) resolveConfig(nodeName string) resolvedConfig {
+	resolved := resolvedConfig{
+		reverseIPDomain: c.reverseIPDomain,
+		databaseIDs:     c.databaseIDs,
+		reservedIPs:     c.reservedIPs,
+	}
+
+	obj, exists, err := c.indexer.GetByKey(nodeName)
+	if err != nil || !exists {
+		return resolved
+	}
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		return resolved
+	}
+
+	configs, err := c.configLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list NodeCoffeeConfigs for node %s: %v", nodeName, err)
+		return resolved
+	}
+
+	var best *nodecoffeev1alpha1.NodeCoffeeConfig
+	for _, config := range configs {
+		selector := labels.SelectorFromValidatedSet(config.Spec.NodeSelector)
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+
+		if best == nil || configIsNewer(config, best) {
+			best = config
+		}
+	}
+
+	if best == nil {
+		return resolved
+	}
+
+	klog.V(4).Infof("node %s: applying NodeCoffeeConfig %s/%s", nodeName, best.Namespace, best.Name)
+
+	if best.Spec.ReverseIPDomain != "" {
+		resolved.reverseIPDomain = best.Spec.ReverseIPDomain
+	}
+	if len(best.Spec.DatabaseIDs) > 0 {
+		resolved.databaseIDs = best.Spec.DatabaseIDs
+	}
+	if len(best.Spec.ReservedIPsPool) > 0 {
+		resolved.reservedIPs = best.Spec.ReservedIPsPool
+	}
+
+	return resolved
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	nodecoffeev1alpha1 "github.com/abarbare/scaleway-k8s-node-coffee/pkg/apis/nodecoffee/v1alpha1"
+	nodecoffeelisters "github.com/abarbare/scaleway-k8s-node-coffee/pkg/generated/listers/nodecoffee/v1alpha1"
+)
+
+func TestConfigIsNewer(t *testing.T) {
+	older := metav1.NewTime(time.Unix(100, 0))
+	newer := metav1.NewTime(time.Unix(200, 0))
+
+	a := &nodecoffeev1alpha1.NodeCoffeeConfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "a", CreationTimestamp: newer}}
+	b := &nodecoffeev1alpha1.NodeCoffeeConfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "b", CreationTimestamp: older}}
+	if !configIsNewer(a, b) {
+		t.Fatalf("configIsNewer(a, b) = false, want true (a was created later)")
+	}
+	if configIsNewer(b, a) {
+		t.Fatalf("configIsNewer(b, a) = true, want false (b was created earlier)")
+	}
+
+	// Same CreationTimestamp: broken deterministically by namespace/name,
+	// not by indexer iteration order.
+	same := metav1.NewTime(time.Unix(100, 0))
+	x := &nodecoffeev1alpha1.NodeCoffeeConfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "x", CreationTimestamp: same}}
+	y := &nodecoffeev1alpha1.NodeCoffeeConfig{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "y", CreationTimestamp: same}}
+	if configIsNewer(x, y) {
+		t.Fatalf("configIsNewer(x, y) = true, want false (\"kube-system/x\" < \"kube-system/y\")")
+	}
+	if !configIsNewer(y, x) {
+		t.Fatalf("configIsNewer(y, x) = false, want true (\"kube-system/y\" > \"kube-system/x\")")
+	}
+}
+
+func newTestController(t *testing.T, nodes []*v1.Node, configs []*nodecoffeev1alpha1.NodeCoffeeConfig) *Controller {
+	t.Helper()
+
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, node := range nodes {
+		if err := nodeIndexer.Add(node); err != nil {
+			t.Fatalf("failed to seed node indexer: %v", err)
+		}
+	}
+
+	configIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, config := range configs {
+		if err := configIndexer.Add(config); err != nil {
+			t.Fatalf("failed to seed config indexer: %v", err)
+		}
+	}
+
+	return &Controller{
+		indexer:         nodeIndexer,
+		configLister:    nodecoffeelisters.NewNodeCoffeeConfigLister(configIndexer),
+		reverseIPDomain: "default.example.com",
+		databaseIDs:     []string{"default-db"},
+		reservedIPs:     []string{"1.2.3.4"},
+	}
+}
+
+func TestResolveConfigFallsBackToDefaultsWithoutMatch(t *testing.T) {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	c := newTestController(t, []*v1.Node{node}, nil)
+
+	got := c.resolveConfig("node-a")
+	if got.reverseIPDomain != "default.example.com" || len(got.databaseIDs) != 1 || got.databaseIDs[0] != "default-db" {
+		t.Fatalf("resolveConfig() = %+v, want the env-derived defaults unchanged", got)
+	}
+}
+
+func TestResolveConfigOverlaysPartialSpec(t *testing.T) {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	config := &nodecoffeev1alpha1.NodeCoffeeConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "only-domain"},
+		Spec:       nodecoffeev1alpha1.NodeCoffeeConfigSpec{ReverseIPDomain: "custom.example.com"},
+	}
+	c := newTestController(t, []*v1.Node{node}, []*nodecoffeev1alpha1.NodeCoffeeConfig{config})
+
+	got := c.resolveConfig("node-a")
+	if got.reverseIPDomain != "custom.example.com" {
+		t.Fatalf("resolveConfig().reverseIPDomain = %q, want override from the matching config", got.reverseIPDomain)
+	}
+	if len(got.databaseIDs) != 1 || got.databaseIDs[0] != "default-db" {
+		t.Fatalf("resolveConfig().databaseIDs = %v, want the env default since the config left it unset", got.databaseIDs)
+	}
+}
+
+func TestResolveConfigPicksNewestMatch(t *testing.T) {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	older := &nodecoffeev1alpha1.NodeCoffeeConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "older", CreationTimestamp: metav1.NewTime(time.Unix(100, 0))},
+		Spec:       nodecoffeev1alpha1.NodeCoffeeConfigSpec{ReverseIPDomain: "old.example.com"},
+	}
+	newer := &nodecoffeev1alpha1.NodeCoffeeConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "newer", CreationTimestamp: metav1.NewTime(time.Unix(200, 0))},
+		Spec:       nodecoffeev1alpha1.NodeCoffeeConfigSpec{ReverseIPDomain: "new.example.com"},
+	}
+	c := newTestController(t, []*v1.Node{node}, []*nodecoffeev1alpha1.NodeCoffeeConfig{older, newer})
+
+	got := c.resolveConfig("node-a")
+	if got.reverseIPDomain != "new.example.com" {
+		t.Fatalf("resolveConfig().reverseIPDomain = %q, want the most recently created match", got.reverseIPDomain)
+	}
+}
+
+func TestResolveConfigSkipsNonMatchingSelector(t *testing.T) {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"pool": "default"}}}
+	config := &nodecoffeev1alpha1.NodeCoffeeConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "gpu-only"},
+		Spec: nodecoffeev1alpha1.NodeCoffeeConfigSpec{
+			ReverseIPDomain: "gpu.example.com",
+			NodeSelector:    map[string]string{"pool": "gpu"},
+		},
+	}
+	c := newTestController(t, []*v1.Node{node}, []*nodecoffeev1alpha1.NodeCoffeeConfig{config})
+
+	got := c.resolveConfig("node-a")
+	if got.reverseIPDomain != "default.example.com" {
+		t.Fatalf("resolveConfig().reverseIPDomain = %q, want the env default since node-a doesn't match the config's NodeSelector", got.reverseIPDomain)
+	}
+}
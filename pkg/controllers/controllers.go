@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -15,6 +16,9 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	klog "k8s.io/klog/v2"
+
+	nodecoffeeclientset "github.com/abarbare/scaleway-k8s-node-coffee/pkg/generated/clientset/versioned"
+	"github.com/abarbare/scaleway-k8s-node-coffee/pkg/metrics"
 )
 
 const (
@@ -23,58 +27,108 @@ const (
 	ReservedIPsPoolEnv = "RESERVED_IPS_POOL"
 )
 
-func NewController(clientset *kubernetes.Clientset) (*Controller, error) {
+// defaultNumberRetries is how many times handleErr re-queues a failing
+// SyncTask, with rate-limited backoff, before giving up on it.
+const defaultNumberRetries = 5
+
+func NewController(clientset *kubernetes.Clientset, configClientset nodecoffeeclientset.Interface) (*Controller, error) {
 	nodeListWatcher := cache.NewListWatchFromClient(clientset.CoreV1().RESTClient(), "nodes", "", fields.Everything())
 
-	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	queue := workqueue.NewTypedRateLimitingQueue[SyncTask](newRateLimiter())
+
+	var controller *Controller
+
+	enqueueNode := func(obj interface{}) {
+		node, ok := obj.(*v1.Node)
+		if !ok {
+			return
+		}
+		for _, task := range tasksForNode(node.Name) {
+			queue.Add(task)
+		}
+	}
 
 	indexer, informer := cache.NewIndexerInformer(nodeListWatcher, &v1.Node{}, 0, cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			key, err := cache.MetaNamespaceKeyFunc(obj)
-			if err == nil {
-				queue.Add(key)
+			enqueueNode(obj)
+			if node, ok := obj.(*v1.Node); ok && controller != nil {
+				controller.ensureFinalizer(node)
 			}
 		},
 		UpdateFunc: func(old interface{}, new interface{}) {
-			key, err := cache.MetaNamespaceKeyFunc(new)
-			if err == nil {
-
-				oldNode, oldOk := old.(*v1.Node)
-				newNode, newOk := new.(*v1.Node)
-				if oldOk && newOk {
-					if oldNode.ResourceVersion == newNode.ResourceVersion {
-						queue.Add(key)
+			oldNode, oldOk := old.(*v1.Node)
+			newNode, newOk := new.(*v1.Node)
+			if !oldOk || !newOk {
+				return
+			}
+
+			if oldNode.ResourceVersion == newNode.ResourceVersion {
+				enqueueNode(newNode)
+				return
+			}
+
+			if oldNode.DeletionTimestamp == nil && newNode.DeletionTimestamp != nil {
+				// The node was just marked for deletion. With
+				// CleanupFinalizer present this is the only signal we get:
+				// the object is blocked from actually being removed, so
+				// DeleteFunc never fires and there's no other event to
+				// trigger the cleanup path.
+				enqueueNode(newNode)
+				return
+			}
+
+			for _, oldAddress := range oldNode.Status.Addresses {
+				for _, newAddress := range newNode.Status.Addresses {
+					if oldAddress.Type == newAddress.Type && oldAddress.Address != newAddress.Address {
+						enqueueNode(newNode)
 						return
 					}
-					for _, oldAddress := range oldNode.Status.Addresses {
-						for _, newAddress := range newNode.Status.Addresses {
-							if oldAddress.Type == newAddress.Type && oldAddress.Address != newAddress.Address {
-								queue.Add(key)
-								return
-							}
-						}
-					}
 				}
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
-			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-			if err == nil {
-				queue.Add(key)
+			node, ok := obj.(*v1.Node)
+			if !ok {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					node, ok = tombstone.Obj.(*v1.Node)
+					if !ok {
+						return
+					}
+				} else {
+					return
+				}
 			}
+			enqueueNode(node)
 		},
 	}, cache.Indexers{})
 
+	configLister, configInformer := newConfigInformer(configClientset, configNamespaceFromEnv(), func(obj interface{}) {
+		if controller != nil {
+			controller.enqueueNodesMatchingConfig(obj)
+		}
+	})
+
 	scwClient, err := scw.NewClient(scw.WithEnv())
 	if err != nil {
 		return nil, err
 	}
 
-	controller := &Controller{
-		indexer:   indexer,
-		informer:  informer,
-		queue:     queue,
-		scwClient: scwClient,
+	controller = &Controller{
+		clientset:       clientset,
+		configClientset: configClientset,
+		indexer:         indexer,
+		informer:        informer,
+		configLister:    configLister,
+		configInformer:  configInformer,
+		queue:           queue,
+		scwClient:       scwClient,
+		numberRetries:   defaultNumberRetries,
+		leaderElection:  leaderElectionConfigFromEnv(),
+		workers:         workersFromEnv(),
+		nodeLocks:       newNodeLocks(),
+		httpAddr:        httpAddrFromEnv(),
+		cleanupOnDelete: cleanupOnDeleteFromEnv(),
+		cleanupProgress: newCleanupProgress(),
 	}
 
 	// TODO handle validation here ?
@@ -93,79 +147,118 @@ func NewController(clientset *kubernetes.Clientset) (*Controller, error) {
 	return controller, nil
 }
 
-func (c *Controller) syncNeeded(nodeName string) error {
-	var errs []error
+func (c *Controller) syncTask(task SyncTask) error {
+	unlock := c.nodeLocks.lock(task.NodeName)
+	defer unlock()
 
-	err := c.syncReservedIP(nodeName)
-	if err != nil {
-		klog.Errorf("failed to sync reserved IP for node %s: %v", nodeName, err)
-		errs = append(errs, err)
-	}
-	err = c.syncReverseIP(nodeName)
-	if err != nil {
-		klog.Errorf("failed to sync reverse IP for node %s: %v", nodeName, err)
-		errs = append(errs, err)
-	}
-	err = c.syncDatabaseACLs(nodeName)
+	start := time.Now()
+	err := c.dispatchSync(task)
+	metrics.SyncDuration.WithLabelValues(string(task.Kind)).Observe(time.Since(start).Seconds())
+
+	result := metrics.ResultSuccess
 	if err != nil {
-		klog.Errorf("failed to sync database acl for node %s: %v", nodeName, err)
-		errs = append(errs, err)
+		result = metrics.ResultError
 	}
+	metrics.SyncTotal.WithLabelValues(string(task.Kind), result).Inc()
 
-	if len(errs) == 0 {
-		return nil
+	return err
+}
+
+func (c *Controller) dispatchSync(task SyncTask) error {
+	if obj, exists, err := c.indexer.GetByKey(task.NodeName); err == nil && exists {
+		if node, ok := obj.(*v1.Node); ok && node.DeletionTimestamp != nil {
+			return c.dispatchCleanup(task, node)
+		}
 	}
 
-	return fmt.Errorf("got several error")
+	cfg := c.resolveConfig(task.NodeName)
+
+	switch task.Kind {
+	case SyncKindReservedIP:
+		return c.syncReservedIP(task.NodeName, cfg)
+	case SyncKindReverseIP:
+		return c.syncReverseIP(task.NodeName, cfg)
+	case SyncKindDatabaseACL:
+		return c.syncDatabaseACLs(task.NodeName, cfg)
+	default:
+		return fmt.Errorf("unknown sync kind %q for node %s", task.Kind, task.NodeName)
+	}
 }
 
 func (c *Controller) processNextItem() bool {
-	key, quit := c.queue.Get()
+	metrics.WorkqueueDepth.Set(float64(c.queue.Len()))
+
+	task, quit := c.queue.Get()
 	if quit {
 		return false
 	}
-	defer c.queue.Done(key)
+	defer c.queue.Done(task)
 
-	err := c.syncNeeded(key.(string))
-	c.handleErr(err, key)
+	err := c.syncTask(task)
+	if err != nil {
+		klog.Errorf("failed to sync %s for node %s: %v", task.Kind, task.NodeName, err)
+	}
+	c.handleErr(err, task)
 	return true
 }
 
-func (c *Controller) handleErr(err error, key interface{}) {
+func (c *Controller) handleErr(err error, task SyncTask) {
 	if err == nil {
-		c.queue.Forget(key)
+		c.queue.Forget(task)
 		return
 	}
 
-	if c.queue.NumRequeues(key) < c.numberRetries {
-		c.queue.AddRateLimited(key)
+	if c.queue.NumRequeues(task) < c.numberRetries {
+		metrics.WorkqueueRetriesTotal.Inc()
+		c.queue.AddRateLimited(task)
 		return
 	}
 
-	c.queue.Forget(key)
+	c.queue.Forget(task)
 	runtime.HandleError(err)
-	klog.Infof("too many retries for key %s: %v", key, err)
+	klog.Infof("too many retries for task %s: %v", task, err)
 }
 
-func (c *Controller) Run(stopCh chan struct{}) {
+// Run blocks until ctx is cancelled, at which point it shuts down the
+// informers, workers and queue and returns. With leader election enabled
+// it also shuts them down and returns once the lease is lost.
+func (c *Controller) Run(ctx context.Context) {
 	defer runtime.HandleCrash()
 	defer c.Wg.Done()
 
-	defer c.queue.ShutDown()
+	c.startHTTPServer(ctx)
 
-	go c.informer.Run(stopCh)
+	runController := func(ctx context.Context) {
+		defer c.queue.ShutDown()
 
-	if !cache.WaitForCacheSync(stopCh, c.informer.HasSynced) {
-		runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
-		return
+		go c.informer.Run(ctx.Done())
+		go c.configInformer.Run(ctx.Done())
+
+		if !cache.WaitForCacheSync(ctx.Done(), c.informer.HasSynced, c.configInformer.HasSynced) {
+			runtime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+			return
+		}
+
+		for i := 0; i < c.workers; i++ {
+			go wait.Until(c.runWorker, time.Second, ctx.Done())
+		}
+
+		<-ctx.Done()
 	}
 
-	go wait.Until(c.runWorker, time.Second, stopCh)
+	if !c.leaderElection.enabled {
+		runController(ctx)
+		return
+	}
 
-	<-stopCh
+	if err := c.runWithLeaderElection(ctx, newLeaderElectionIdentity(), runController); err != nil {
+		runtime.HandleError(err)
+	}
 }
 
 func (c *Controller) runWorker() {
+	defer runtime.HandleCrash()
+
 	for c.processNextItem() {
 	}
 }
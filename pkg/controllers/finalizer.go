@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	klog "k8s.io/klog/v2"
+)
+
+// CleanupFinalizer is added to every node node-coffee manages, so that
+// once a Node is marked for deletion it still has its addresses
+// available in the indexer to release its reserved IP, reset its
+// reverse DNS, and drop it from the DATABASE_IDS ACLs, instead of racing
+// the API server's garbage collection of the Node object.
+const CleanupFinalizer = "nodecoffee.scaleway.com/cleanup"
+
+// CleanupOnDeleteEnv lets operators opt out of the controller patching
+// nodes to add/remove CleanupFinalizer, for clusters that manage
+// cleanup of orphaned Scaleway resources another way.
+const CleanupOnDeleteEnv = "CLEANUP_ON_DELETE"
+
+const finalizerFieldManager = "node-coffee"
+
+func cleanupOnDeleteFromEnv() bool {
+	v := os.Getenv(CleanupOnDeleteEnv)
+	if v == "" {
+		return true
+	}
+
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		klog.Warningf("invalid %s value %q, keeping cleanup on delete enabled", CleanupOnDeleteEnv, v)
+		return true
+	}
+	return enabled
+}
+
+func hasCleanupFinalizer(node *v1.Node) bool {
+	for _, f := range node.Finalizers {
+		if f == CleanupFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureFinalizer adds CleanupFinalizer to node if cleanup-on-delete is
+// enabled and the node doesn't already have it.
+func (c *Controller) ensureFinalizer(node *v1.Node) {
+	if !c.cleanupOnDelete || node.DeletionTimestamp != nil || hasCleanupFinalizer(node) {
+		return
+	}
+
+	op := map[string]interface{}{"op": "add", "path": "/metadata/finalizers/-", "value": CleanupFinalizer}
+	if len(node.Finalizers) == 0 {
+		// Appending with "-" fails when the finalizers array is absent.
+		op = map[string]interface{}{"op": "add", "path": "/metadata/finalizers", "value": []string{CleanupFinalizer}}
+	}
+	patch, err := json.Marshal([]map[string]interface{}{op})
+	if err != nil {
+		klog.Errorf("failed to marshal finalizer patch for node %s: %v", node.Name, err)
+		return
+	}
+
+	_, err = c.clientset.CoreV1().Nodes().Patch(context.Background(), node.Name, types.JSONPatchType, patch, metav1.PatchOptions{FieldManager: finalizerFieldManager})
+	if err != nil {
+		klog.Errorf("failed to add cleanup finalizer to node %s: %v", node.Name, err)
+	}
+}
+
+// removeFinalizer drops CleanupFinalizer from node, a no-op if it's
+// already gone.
+func (c *Controller) removeFinalizer(node *v1.Node) error {
+	finalizers := make([]string, 0, len(node.Finalizers))
+	for _, f := range node.Finalizers {
+		if f != CleanupFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	if len(finalizers) == len(node.Finalizers) {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"finalizers": finalizers},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.clientset.CoreV1().Nodes().Patch(context.Background(), node.Name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: finalizerFieldManager})
+	return err
+}
+
+// dispatchCleanup runs the cleanup path for a single subsystem of a node
+// that's being deleted, and removes node's finalizer once every
+// subsystem has been cleaned up.
+func (c *Controller) dispatchCleanup(task SyncTask, node *v1.Node) error {
+	var err error
+	switch task.Kind {
+	case SyncKindReservedIP:
+		err = c.cleanupReservedIP(task.NodeName)
+	case SyncKindReverseIP:
+		err = c.cleanupReverseIP(task.NodeName)
+	case SyncKindDatabaseACL:
+		err = c.cleanupDatabaseACL(task.NodeName)
+	default:
+		return fmt.Errorf("unknown sync kind %q for node %s", task.Kind, task.NodeName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !c.cleanupProgress.markDone(task.NodeName, task.Kind) {
+		return nil
+	}
+
+	if err := c.removeFinalizer(node); err != nil {
+		return fmt.Errorf("failed to remove cleanup finalizer from node %s: %w", task.NodeName, err)
+	}
+	klog.Infof("node %s: released reserved IP, reset reverse DNS and cleared database ACLs; removed cleanup finalizer", task.NodeName)
+
+	return nil
+}
+
+// errCleanupNotImplemented is returned by the cleanup* stubs below. It
+// keeps dispatchCleanup's err != nil branch from reaching
+// removeFinalizer, so nodes are never stripped of CleanupFinalizer as
+// if a subsystem had actually been released.
+var errCleanupNotImplemented = errors.New("cleanup not implemented")
+
+// cleanupReservedIP is a stub: it does not yet call the Scaleway API to
+// release nodeName's reserved IP. It returns errCleanupNotImplemented so
+// the node keeps its finalizer and retries instead of being reported as
+// cleaned up.
+//
+// TODO(chunk0-6 follow-up): release the reserved IP on the Scaleway
+// instance API once syncReservedIP's own Scaleway wiring lands; the two
+// should share whatever client helper that introduces.
+func (c *Controller) cleanupReservedIP(nodeName string) error {
+	klog.Warningf("node %s: cleanupReservedIP is not implemented, leaving finalizer in place", nodeName)
+	return errCleanupNotImplemented
+}
+
+// cleanupReverseIP is a stub; see cleanupReservedIP.
+//
+// TODO(chunk0-6 follow-up): reset nodeName's reverse DNS record on the
+// Scaleway domain API.
+func (c *Controller) cleanupReverseIP(nodeName string) error {
+	klog.Warningf("node %s: cleanupReverseIP is not implemented, leaving finalizer in place", nodeName)
+	return errCleanupNotImplemented
+}
+
+// cleanupDatabaseACL is a stub; see cleanupReservedIP.
+//
+// TODO(chunk0-6 follow-up): drop nodeName's IP from the configured
+// DATABASE_IDS' ACLs on the Scaleway RDB API.
+func (c *Controller) cleanupDatabaseACL(nodeName string) error {
+	klog.Warningf("node %s: cleanupDatabaseACL is not implemented, leaving finalizer in place", nodeName)
+	return errCleanupNotImplemented
+}
+
+// cleanupProgress tracks, per node being deleted, which subsystems have
+// finished their cleanup, so the finalizer is only removed once all of
+// them have.
+type cleanupProgress struct {
+	mu   sync.Mutex
+	done map[string]map[SyncKind]bool
+}
+
+func newCleanupProgress() *cleanupProgress {
+	return &cleanupProgress{done: make(map[string]map[SyncKind]bool)}
+}
+
+// markDone records that nodeName's kind subsystem finished cleanup, and
+// reports whether every subsystem for that node is now done (in which
+// case its bookkeeping is cleared).
+func (p *cleanupProgress) markDone(nodeName string, kind SyncKind) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	kinds, ok := p.done[nodeName]
+	if !ok {
+		kinds = make(map[SyncKind]bool, len(allSyncKinds))
+		p.done[nodeName] = kinds
+	}
+	kinds[kind] = true
+
+	for _, k := range allSyncKinds {
+		if !kinds[k] {
+			return false
+		}
+	}
+
+	delete(p.done, nodeName)
+	return true
+}
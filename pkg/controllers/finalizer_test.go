@@ -0,0 +1,52 @@
+package controllers
+
+import "testing"
+
+func TestCleanupProgressMarkDone(t *testing.T) {
+	p := newCleanupProgress()
+
+	if p.markDone("node-a", SyncKindReservedIP) {
+		t.Fatalf("markDone() = true after only 1/%d subsystems done", len(allSyncKinds))
+	}
+	if p.markDone("node-a", SyncKindReverseIP) {
+		t.Fatalf("markDone() = true after only 2/%d subsystems done", len(allSyncKinds))
+	}
+	if !p.markDone("node-a", SyncKindDatabaseACL) {
+		t.Fatalf("markDone() = false after all %d subsystems done", len(allSyncKinds))
+	}
+}
+
+func TestCleanupProgressMarkDoneIsPerNode(t *testing.T) {
+	p := newCleanupProgress()
+
+	p.markDone("node-a", SyncKindReservedIP)
+	p.markDone("node-a", SyncKindReverseIP)
+
+	if p.markDone("node-b", SyncKindDatabaseACL) {
+		t.Fatalf("markDone() = true for node-b, which has only 1 subsystem done")
+	}
+}
+
+func TestCleanupProgressMarkDoneResetsAfterCompletion(t *testing.T) {
+	p := newCleanupProgress()
+
+	for _, kind := range allSyncKinds {
+		p.markDone("node-a", kind)
+	}
+
+	if p.markDone("node-a", SyncKindReservedIP) {
+		t.Fatalf("markDone() = true after bookkeeping should have been cleared and restarted")
+	}
+}
+
+func TestCleanupProgressMarkDoneIsIdempotent(t *testing.T) {
+	p := newCleanupProgress()
+
+	p.markDone("node-a", SyncKindReservedIP)
+	p.markDone("node-a", SyncKindReservedIP)
+	p.markDone("node-a", SyncKindReverseIP)
+
+	if p.markDone("node-a", SyncKindReservedIP) {
+		t.Fatalf("markDone() = true after re-marking ReservedIP instead of completing DatabaseACL")
+	}
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	klog "k8s.io/klog/v2"
+)
+
+// HTTPAddrEnv overrides the address Run serves /metrics, /healthz and
+// /readyz on.
+const HTTPAddrEnv = "HTTP_ADDR"
+
+const defaultHTTPAddr = ":8080"
+
+func httpAddrFromEnv() string {
+	if v := os.Getenv(HTTPAddrEnv); v != "" {
+		return v
+	}
+	return defaultHTTPAddr
+}
+
+// startHTTPServer serves Prometheus metrics and liveness/readiness
+// probes for the lifetime of ctx. It runs on every replica regardless of
+// leader election status, so standbys stay scrapable and remain live.
+func (c *Controller) startHTTPServer(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !c.informer.HasSynced() || !c.configInformer.HasSynced() {
+			http.Error(w, "caches not synced", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := &http.Server{Addr: c.httpAddr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.Errorf("failed to shut down http server: %v", err)
+		}
+	}()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			runtime.HandleError(err)
+		}
+	}()
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	WorkqueueBaseDelayEnv   = "WORKQUEUE_BASE_DELAY"
+	WorkqueueMaxDelayEnv    = "WORKQUEUE_MAX_DELAY"
+	WorkqueueBucketQPSEnv   = "WORKQUEUE_BUCKET_QPS"
+	WorkqueueBucketBurstEnv = "WORKQUEUE_BUCKET_BURST"
+
+	defaultWorkqueueBaseDelay   = 5 * time.Millisecond
+	defaultWorkqueueMaxDelay    = 1000 * time.Second
+	defaultWorkqueueBucketQPS   = 10
+	defaultWorkqueueBucketBurst = 100
+)
+
+// newRateLimiter builds the TypedRateLimiter used by the sync task
+// workqueue: an exponential backoff on repeated failures for a given
+// task, capped by an overall token bucket so a burst of failing nodes
+// can't hammer the Scaleway API. Both halves are tunable via env vars so
+// operators can relax or tighten retry aggressiveness for flaky Scaleway
+// API responses.
+func newRateLimiter() workqueue.TypedRateLimiter[SyncTask] {
+	baseDelay := defaultWorkqueueBaseDelay
+	if d, ok := durationFromEnv(WorkqueueBaseDelayEnv); ok {
+		baseDelay = d
+	}
+
+	maxDelay := defaultWorkqueueMaxDelay
+	if d, ok := durationFromEnv(WorkqueueMaxDelayEnv); ok {
+		maxDelay = d
+	}
+
+	qps := defaultWorkqueueBucketQPS
+	if v, ok := intFromEnv(WorkqueueBucketQPSEnv); ok {
+		qps = v
+	}
+
+	burst := defaultWorkqueueBucketBurst
+	if v, ok := intFromEnv(WorkqueueBucketBurstEnv); ok {
+		burst = v
+	}
+
+	return workqueue.NewTypedMaxOfRateLimiter[SyncTask](
+		workqueue.NewTypedItemExponentialFailureRateLimiter[SyncTask](baseDelay, maxDelay),
+		&workqueue.TypedBucketRateLimiter[SyncTask]{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
+}
+
+func intFromEnv(key string) (int, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+
+	return i, true
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntFromEnv(t *testing.T) {
+	const key = "TEST_INT_FROM_ENV"
+
+	tests := []struct {
+		name   string
+		value  string
+		set    bool
+		want   int
+		wantOk bool
+	}{
+		{name: "unset", set: false, want: 0, wantOk: false},
+		{name: "empty", value: "", set: true, want: 0, wantOk: false},
+		{name: "valid", value: "42", set: true, want: 42, wantOk: true},
+		{name: "negative", value: "-5", set: true, want: -5, wantOk: true},
+		{name: "not a number", value: "abc", set: true, want: 0, wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				t.Setenv(key, tt.value)
+			}
+
+			got, ok := intFromEnv(key)
+			if ok != tt.wantOk {
+				t.Fatalf("intFromEnv() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Fatalf("intFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationFromEnv(t *testing.T) {
+	const key = "TEST_DURATION_FROM_ENV"
+
+	tests := []struct {
+		name   string
+		value  string
+		set    bool
+		want   time.Duration
+		wantOk bool
+	}{
+		{name: "unset", set: false, want: 0, wantOk: false},
+		{name: "empty", value: "", set: true, want: 0, wantOk: false},
+		{name: "valid", value: "5s", set: true, want: 5 * time.Second, wantOk: true},
+		{name: "not a duration", value: "soon", set: true, want: 0, wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				t.Setenv(key, tt.value)
+			}
+
+			got, ok := durationFromEnv(key)
+			if ok != tt.wantOk {
+				t.Fatalf("durationFromEnv() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Fatalf("durationFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
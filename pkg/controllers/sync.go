@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"errors"
+
+	klog "k8s.io/klog/v2"
+)
+
+// errSyncNotImplemented is returned by the sync* stubs below: the
+// Scaleway API calls they're meant to make were never wired up, in this
+// series or the baseline before it, so dispatchSync's callers (syncTask,
+// and in turn processNextItem's retry loop) keep retrying instead of
+// being told a node is in sync when it isn't.
+var errSyncNotImplemented = errors.New("sync not implemented")
+
+// syncReservedIP is a stub: it does not yet call the Scaleway instance
+// API to attach one of cfg.reservedIPs to nodeName.
+//
+// TODO(chunk0-3 follow-up): attach/detach nodeName's reserved IP via the
+// Scaleway instance API, scoped to cfg.reservedIPs rather than the
+// package-wide RESERVED_IPS_POOL default.
+func (c *Controller) syncReservedIP(nodeName string, cfg resolvedConfig) error {
+	klog.Warningf("node %s: syncReservedIP is not implemented (pool %v)", nodeName, cfg.reservedIPs)
+	return errSyncNotImplemented
+}
+
+// syncReverseIP is a stub; see syncReservedIP.
+//
+// TODO(chunk0-3 follow-up): publish nodeName's reverse DNS record under
+// cfg.reverseIPDomain via the Scaleway domain API.
+func (c *Controller) syncReverseIP(nodeName string, cfg resolvedConfig) error {
+	klog.Warningf("node %s: syncReverseIP is not implemented (domain %q)", nodeName, cfg.reverseIPDomain)
+	return errSyncNotImplemented
+}
+
+// syncDatabaseACLs is a stub; see syncReservedIP.
+//
+// TODO(chunk0-3 follow-up): allow-list nodeName's public IP on each of
+// cfg.databaseIDs via the Scaleway RDB API.
+func (c *Controller) syncDatabaseACLs(nodeName string, cfg resolvedConfig) error {
+	klog.Warningf("node %s: syncDatabaseACLs is not implemented (database IDs %v)", nodeName, cfg.databaseIDs)
+	return errSyncNotImplemented
+}
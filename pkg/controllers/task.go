@@ -0,0 +1,35 @@
+package controllers
+
+// SyncKind identifies which Scaleway-backed subsystem a SyncTask targets.
+type SyncKind string
+
+const (
+	SyncKindReservedIP  SyncKind = "ReservedIP"
+	SyncKindReverseIP   SyncKind = "ReverseIP"
+	SyncKindDatabaseACL SyncKind = "DatabaseACL"
+)
+
+// allSyncKinds is every subsystem a node is synced (or cleaned up) on,
+// used to build per-node task lists and to know when all of a node's
+// cleanup tasks have completed.
+var allSyncKinds = []SyncKind{SyncKindReservedIP, SyncKindReverseIP, SyncKindDatabaseACL}
+
+// SyncTask is a unit of work enqueued for a single node and a single
+// subsystem, so that a failure in one subsystem only retries that
+// subsystem instead of re-running every sync for the node.
+type SyncTask struct {
+	NodeName string
+	Kind     SyncKind
+}
+
+func (t SyncTask) String() string {
+	return string(t.Kind) + "/" + t.NodeName
+}
+
+func tasksForNode(nodeName string) []SyncTask {
+	tasks := make([]SyncTask, 0, len(allSyncKinds))
+	for _, kind := range allSyncKinds {
+		tasks = append(tasks, SyncTask{NodeName: nodeName, Kind: kind})
+	}
+	return tasks
+}
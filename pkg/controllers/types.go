@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"sync"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	nodecoffeeclientset "github.com/abarbare/scaleway-k8s-node-coffee/pkg/generated/clientset/versioned"
+	nodecoffeelisters "github.com/abarbare/scaleway-k8s-node-coffee/pkg/generated/listers/nodecoffee/v1alpha1"
+)
+
+// Controller watches Kubernetes Nodes and NodeCoffeeConfig objects, and
+// reconciles each node's reserved IP, reverse DNS and database ACL state
+// against the Scaleway API.
+type Controller struct {
+	clientset       *kubernetes.Clientset
+	configClientset nodecoffeeclientset.Interface
+
+	indexer  cache.Indexer
+	informer cache.Controller
+	queue    workqueue.TypedRateLimitingInterface[SyncTask]
+
+	// configLister/configInformer track NodeCoffeeConfig objects in the
+	// namespace returned by configNamespaceFromEnv, via the generated
+	// informer/lister.
+	configLister   nodecoffeelisters.NodeCoffeeConfigLister
+	configInformer cache.Controller
+
+	scwClient *scw.Client
+
+	// reverseIPDomain, databaseIDs and reservedIPs are the env-derived
+	// defaults used by resolveConfig when no NodeCoffeeConfig matches a
+	// node.
+	reverseIPDomain string
+	databaseIDs     []string
+	reservedIPs     []string
+
+	numberRetries int
+
+	// workers is how many runWorker goroutines Run spawns; see WorkersEnv.
+	workers int
+	// nodeLocks serializes concurrent syncs of the same node across workers.
+	nodeLocks *nodeLocks
+
+	// httpAddr is where Run serves /metrics, /healthz and /readyz; see HTTPAddrEnv.
+	httpAddr string
+
+	// cleanupOnDelete gates whether the controller adds/removes
+	// CleanupFinalizer on nodes; see CleanupOnDeleteEnv.
+	cleanupOnDelete bool
+	// cleanupProgress tracks per-node, per-subsystem cleanup completion
+	// while a node is being deleted.
+	cleanupProgress *cleanupProgress
+
+	// Wg is signalled once Run has returned, letting callers wait for a
+	// clean shutdown before exiting the process.
+	Wg sync.WaitGroup
+
+	leaderElection leaderElectionConfig
+}
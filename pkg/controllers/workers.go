@@ -0,0 +1,46 @@
+package controllers
+
+import "sync"
+
+// WorkersEnv overrides how many worker goroutines process the sync task
+// queue concurrently. Defaults to defaultWorkers so existing deployments
+// keep today's single-worker, fully-serialized behavior.
+const WorkersEnv = "WORKERS"
+
+const defaultWorkers = 1
+
+func workersFromEnv() int {
+	if v, ok := intFromEnv(WorkersEnv); ok && v > 0 {
+		return v
+	}
+	return defaultWorkers
+}
+
+// nodeLocks serializes syncs of the same node across workers. The
+// workqueue already guarantees that a given SyncTask key is only
+// processed by one worker at a time, but since each node now has one
+// task per subsystem, two different tasks for the same node (e.g. its
+// ReservedIP and ReverseIP syncs) can still land on two workers at once;
+// that would race on the node's Scaleway resources.
+type nodeLocks struct {
+	mu    sync.Mutex
+	perID map[string]*sync.Mutex
+}
+
+func newNodeLocks() *nodeLocks {
+	return &nodeLocks{perID: make(map[string]*sync.Mutex)}
+}
+
+// lock blocks until nodeName's lock is held and returns a func to release it.
+func (n *nodeLocks) lock(nodeName string) func() {
+	n.mu.Lock()
+	l, ok := n.perID[nodeName]
+	if !ok {
+		l = &sync.Mutex{}
+		n.perID[nodeName] = l
+	}
+	n.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}